@@ -15,6 +15,13 @@ typedef struct _tag_lexer_ {
 	char *ptr;
 	size_t size;
 	size_t cur;
+
+	// the position of the current character, 1-based
+	size_t line;
+	size_t col;
+	// the position of the first character of the last token
+	size_t tok_line;
+	size_t tok_col;
 } Lexer;
 
 static int open_lexer(Lexer *lexer, const char *filepath) {
@@ -33,6 +40,7 @@ static int open_lexer(Lexer *lexer, const char *filepath) {
 	}
 
 	lexer->cur = 0;
+	lexer->line = lexer->col = 1;
 	lexer->size = st.st_size;
 	/* load the source code into memory and process as long char array */
 	if (MAP_FAILED == (lexer->ptr = mmap(NULL, lexer->size, PROT_READ, MAP_PRIVATE, lexer->fd, 0))) {
@@ -52,8 +60,9 @@ static void close_lexer(Lexer *lexer) {
 	return;
 }
 
+// return the length of the next token, 0 for the illegal token and -1 for end-of-file
 static int next_token(Lexer *lexer, char *token, size_t token_len) {
-	int len = 0;
+	int len = 0, illegal = 0;
 
 	if (lexer->cur >= lexer->size) {
 		_D(INFO, "end-of-file");
@@ -63,21 +72,49 @@ static int next_token(Lexer *lexer, char *token, size_t token_len) {
 	for (; lexer->cur < lexer->size; ++lexer->cur) {
 		switch (lexer->ptr[lexer->cur]) {
 			case ' ': case '\t': case '\n': case '\r':
-				// get next token
-				lexer->cur ++;
-				goto END;
+				// get next token, the white-space is skipped on the next call
+				if (0 != len || illegal) goto END;
+
+				// skip the leading white-space, e.g. the '\n' in the CRLF
+				if ('\n' == lexer->ptr[lexer->cur]) {
+					lexer->line ++;
+					lexer->col = 1;
+					break;
+				}
+
+				lexer->col ++;
+				break;
 			default:
-				if (len == token_len) {
-					_D(CRIT, "not support token size > %zd", token_len);
-					return -1;
+				if (0 == len && !illegal) {
+					lexer->tok_line = lexer->line;
+					lexer->tok_col = lexer->col;
+				}
+
+				if (illegal || len + 1 == token_len) {
+					// drop the rest of the illegal token and keep scanning
+					illegal = 1;
+					lexer->col ++;
+					break;
 				}
 
 				token[len++] = lexer->ptr[lexer->cur];
+				lexer->col ++;
 				break;
 		}
 	}
 
+	if (0 == len && !illegal) {
+		// only the trailing white-space left
+		_D(INFO, "end-of-file");
+		return -1;
+	}
+
 END:
+	if (illegal) {
+		_D(CRIT, "not support token size >= %zu at L#%zu C#%zu", token_len, lexer->tok_line, lexer->tok_col);
+		len = 0;
+	}
+
 	token[len] = '\0';
 	return len;
 }
@@ -95,9 +132,21 @@ int compile(const char *filepath) {
 		goto END;
 	}	
 
+	int len, errors = 0;
 	char token[MAX_TOKEN_LEN] = {0};
-	while (0 <= next_token(&lexer, token, MAX_TOKEN_LEN)) {
-		_D(WARN, "throw token '%s'", token);
+	while (0 <= (len = next_token(&lexer, token, MAX_TOKEN_LEN))) {
+		if (0 == len) {
+			// collect the illegal token and keep processing the rest of the file
+			errors ++;
+			continue;
+		}
+
+		_D(WARN, "throw token '%s' at L#%zu C#%zu", token, lexer.tok_line, lexer.tok_col);
+	}
+
+	if (errors) {
+		_D(CRIT, "found %d illegal token(s) in '%s'", errors, filepath);
+		goto END;
 	}
 
 	ret = 0;